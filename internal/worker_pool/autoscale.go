@@ -0,0 +1,75 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// Config - параметры создания пула вместе с настройками автомасштабирования.
+// Нулевые значения AutoScale-полей означают, что автомасштабирование выключено
+type Config struct {
+	NumberOfWorkers int
+	QueueSize       int
+	Hook            func()
+	Queue           Queue //опциональная реализация Queue; если nil, используется chanQueue(QueueSize)
+
+	AutoScale          bool          //включает автоматическое изменение числа воркеров
+	MinWorkers         int           //нижняя граница числа воркеров при автомасштабировании
+	MaxWorkers         int           //верхняя граница числа воркеров при автомасштабировании
+	ScaleUpThreshold   int           //глубина очереди, при превышении которой добавляется воркер
+	ScaleDownIdleAfter time.Duration //через сколько простоя очереди один воркер убирается
+	CheckInterval      time.Duration //как часто автоскейлер проверяет состояние очереди
+}
+
+// NewWorkerPoolWithConfig создает пул по конфигу и, если включен AutoScale,
+// запускает фоновую горутину, подстраивающую число воркеров под глубину очереди
+func NewWorkerPoolWithConfig(ctx context.Context, cfg Config) *WorkerPool {
+	var opts []Option
+	if cfg.Queue != nil {
+		opts = append(opts, WithQueue(cfg.Queue))
+	}
+	wp := NewWorkerPool(ctx, cfg.NumberOfWorkers, cfg.QueueSize, cfg.Hook, opts...)
+	if cfg.AutoScale {
+		go wp.runAutoscaler(cfg)
+	}
+	return wp
+}
+
+// runAutoscaler - цикл автомасштабирования: растит пул при переполнении очереди
+// и сокращает его после периода простоя
+func (p *WorkerPool) runAutoscaler(cfg Config) {
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var idleSince time.Time
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			depth := p.queue.Len()
+			current := p.WorkerCount()
+
+			if depth > cfg.ScaleUpThreshold && current < cfg.MaxWorkers {
+				p.Resize(current + 1)
+				idleSince = time.Time{}
+				continue
+			}
+
+			if depth == 0 {
+				if idleSince.IsZero() {
+					idleSince = time.Now()
+				} else if time.Since(idleSince) >= cfg.ScaleDownIdleAfter && current > cfg.MinWorkers {
+					p.Resize(current - 1)
+					idleSince = time.Now()
+				}
+			} else {
+				idleSince = time.Time{}
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// blockingPollInterval - с каким интервалом SubmitBlocking опрашивает Enqueue в ожидании
+// свободного места, когда подключенная Queue не реализует BlockingEnqueuer (например
+// PostgresQueue). Для очереди по умолчанию (chanQueue) опрос не используется - см. ниже
+const blockingPollInterval = 5 * time.Millisecond
+
+// SubmitBlocking добавляет задачу в очередь, ожидая появления свободного места,
+// если она переполнена. Ожидание прерывается отменой ctx или остановкой пула,
+// в последнем случае возвращается ErrPoolStopped
+func (p *WorkerPool) SubmitBlocking(ctx context.Context, task func()) error {
+	p.mu.RLock()
+	if p.isStopped {
+		p.mu.RUnlock()
+		return ErrPoolStopped
+	}
+	// регистрируемся как блокирующий отправитель, пока еще держим RLock, чтобы
+	// Stop не мог закрыть очередь между проверкой isStopped и регистрацией
+	p.blockingWG.Add(1)
+	p.mu.RUnlock()
+	defer p.blockingWG.Done()
+
+	if be, ok := p.queue.(BlockingEnqueuer); ok {
+		return p.submitBlockingWait(ctx, be, task)
+	}
+	return p.submitBlockingPoll(ctx, task)
+}
+
+// submitBlockingWait ждет свободного места через настоящий wakeup очереди (select по
+// каналу внутри chanQueue), вместо опроса - чтобы ожидание не засоряло tasksRejectedFull
+// промежуточными "якобы отказами" и не грузило CPU лишними попытками Enqueue
+func (p *WorkerPool) submitBlockingWait(ctx context.Context, be BlockingEnqueuer, task func()) error {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// будим ожидание при остановке пула, даже если вызывающий ctx не отменен
+	go func() {
+		select {
+		case <-p.stopCh:
+			cancel()
+		case <-waitCtx.Done():
+		}
+	}()
+
+	if err := be.EnqueueBlocking(waitCtx, p.instrument("", task)); err != nil {
+		if errors.Is(err, context.Canceled) && ctx.Err() == nil {
+			return ErrPoolStopped
+		}
+		return err
+	}
+
+	p.recordSubmitted()
+	return nil
+}
+
+// submitBlockingPoll - запасной путь ожидания для очередей, не реализующих
+// BlockingEnqueuer. Опрашивает Enqueue с интервалом blockingPollInterval, не используя
+// submitNamed, чтобы промежуточные ErrQueueFull не инкрементировали tasksRejectedFull -
+// вызывающий не был отклонен, он все еще ждет
+func (p *WorkerPool) submitBlockingPoll(ctx context.Context, task func()) error {
+	ticker := time.NewTicker(blockingPollInterval)
+	defer ticker.Stop()
+
+	for {
+		p.mu.RLock()
+		if p.isStopped {
+			p.mu.RUnlock()
+			return ErrPoolStopped
+		}
+		err := p.queue.Enqueue(p.instrument("", task))
+		p.mu.RUnlock()
+
+		switch {
+		case err == nil:
+			p.recordSubmitted()
+			return nil
+		case errors.Is(err, ErrQueueFull):
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-p.stopCh:
+				return ErrPoolStopped
+			case <-ticker.C:
+			}
+		default:
+			return err
+		}
+	}
+}
+
+// SubmitTimeout - то же самое, что SubmitBlocking, но ожидание места в очереди
+// ограничено таймаутом d
+func (p *WorkerPool) SubmitTimeout(task func(), d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return p.SubmitBlocking(ctx, task)
+}
@@ -0,0 +1,125 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fillQueue занимает единственного воркера блокирующейся задачей и дожидается,
+// что она реально начала выполняться, а затем заполняет обе ячейки очереди
+// задачами-заглушками, чтобы дальнейшие Submit гарантированно получали ErrQueueFull
+func fillQueue(t *testing.T, pool *WorkerPool, block <-chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{})
+	if err := pool.Submit(func() {
+		close(started)
+		<-block
+	}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	<-started
+
+	if err := pool.Submit(func() {}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if err := pool.Submit(func() {}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+}
+
+// тест ожидания места в очереди с последующей успешной отправкой
+func TestSubmitBlockingWaitsForSpace(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 2, nil)
+	defer pool.Stop()
+
+	block := make(chan struct{})
+	fillQueue(t, pool, block)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- pool.SubmitBlocking(context.Background(), func() {})
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("SubmitBlocking returned before queue had space")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(block)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected SubmitBlocking to succeed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("SubmitBlocking did not unblock after space freed up")
+	}
+}
+
+// тест отмены ожидания по контексту вызывающего
+func TestSubmitBlockingCtxCancelled(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 2, nil)
+	defer pool.Stop()
+
+	block := make(chan struct{})
+	defer close(block)
+	fillQueue(t, pool, block)
+
+	callCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := pool.SubmitBlocking(callCtx, func() {})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+// тест пробуждения блокирующего отправителя остановкой пула
+func TestSubmitBlockingWakesOnStop(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 2, nil)
+
+	block := make(chan struct{})
+	fillQueue(t, pool, block)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- pool.SubmitBlocking(context.Background(), func() {})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(block)
+	pool.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil && err != ErrPoolStopped {
+			t.Errorf("Expected nil or ErrPoolStopped, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("SubmitBlocking did not return after Stop")
+	}
+}
+
+// тест SubmitTimeout на переполненной очереди
+func TestSubmitTimeout(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 2, nil)
+	defer pool.Stop()
+
+	block := make(chan struct{})
+	defer close(block)
+	fillQueue(t, pool, block)
+
+	err := pool.SubmitTimeout(func() {}, 20*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// TaskCtx - задача, принимающая контекст, с помощью которого она может прервать
+// свою работу по таймауту или внешней отмене
+type TaskCtx func(ctx context.Context) error
+
+// CancelToken - идентификатор задачи, отправленной с опцией WithCancelToken,
+// по которому её можно отменить через Cancel
+type CancelToken uint64
+
+var lastCancelToken uint64
+
+// NewCancelToken генерирует очередной уникальный CancelToken
+func NewCancelToken() CancelToken {
+	return CancelToken(atomic.AddUint64(&lastCancelToken, 1))
+}
+
+// submitOptions - параметры выполнения задачи, собираемые из SubmitOption-ов
+type submitOptions struct {
+	timeout        time.Duration
+	deadline       time.Time
+	hasDeadline    bool
+	cancelToken    CancelToken
+	hasCancelToken bool
+}
+
+// SubmitOption - функциональная опция для SubmitCtx
+type SubmitOption func(*submitOptions)
+
+// WithTimeout ограничивает время выполнения задачи таймаутом относительно момента её запуска
+func WithTimeout(d time.Duration) SubmitOption {
+	return func(o *submitOptions) {
+		o.timeout = d
+	}
+}
+
+// WithDeadline ограничивает время выполнения задачи конкретным моментом времени
+func WithDeadline(t time.Time) SubmitOption {
+	return func(o *submitOptions) {
+		o.deadline = t
+		o.hasDeadline = true
+	}
+}
+
+// WithCancelToken привязывает к задаче идентификатор, по которому её можно прервать через Cancel
+func WithCancelToken(id CancelToken) SubmitOption {
+	return func(o *submitOptions) {
+		o.cancelToken = id
+		o.hasCancelToken = true
+	}
+}
+
+// SubmitCtx добавляет в пул задачу, принимающую контекст. Контекст задачи является
+// дочерним по отношению к контексту пула, поэтому отменяется автоматически при Stop
+func (p *WorkerPool) SubmitCtx(task TaskCtx, opts ...SubmitOption) error {
+	var o submitOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	taskCtx, cancel := context.WithCancel(p.ctx)
+
+	if o.hasCancelToken {
+		p.cancelFuncs.Store(o.cancelToken, cancel)
+	}
+
+	err := p.Submit(func() {
+		defer cancel()
+		if o.hasCancelToken {
+			defer p.cancelFuncs.Delete(o.cancelToken)
+		}
+		execCtx, execCancel := p.deriveContext(taskCtx, o)
+		defer execCancel()
+		_ = task(execCtx)
+	})
+	if err != nil {
+		cancel()
+		if o.hasCancelToken {
+			p.cancelFuncs.Delete(o.cancelToken)
+		}
+	}
+	return err
+}
+
+// deriveContext строит от parent контекст выполнения задачи с учетом таймаута/дедлайна,
+// заданных опциями. Вызывается в момент запуска задачи воркером, поэтому WithTimeout
+// отсчитывается от начала выполнения, а не от отправки
+func (p *WorkerPool) deriveContext(parent context.Context, o submitOptions) (context.Context, context.CancelFunc) {
+	switch {
+	case o.timeout > 0:
+		return context.WithTimeout(parent, o.timeout)
+	case o.hasDeadline:
+		return context.WithDeadline(parent, o.deadline)
+	default:
+		return context.WithCancel(parent)
+	}
+}
+
+// Cancel прерывает задачу, отправленную с опцией WithCancelToken(id) - как ожидающую
+// в очереди, так и уже выполняющуюся
+func (p *WorkerPool) Cancel(id CancelToken) error {
+	v, ok := p.cancelFuncs.Load(id)
+	if !ok {
+		return ErrUnknownTask
+	}
+	v.(context.CancelFunc)()
+	return nil
+}
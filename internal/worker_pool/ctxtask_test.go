@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// тест срабатывания таймаута задачи
+func TestSubmitCtxTimeout(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	done := make(chan error, 1)
+	err := pool.SubmitCtx(func(taskCtx context.Context) error {
+		<-taskCtx.Done()
+		done <- taskCtx.Err()
+		return taskCtx.Err()
+	}, WithTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("SubmitCtx failed: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Task context was not cancelled by timeout")
+	}
+}
+
+// тест внешней отмены задачи через Cancel во время выполнения
+func TestSubmitCtxExternalCancel(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+
+	token := NewCancelToken()
+	err := pool.SubmitCtx(func(taskCtx context.Context) error {
+		close(started)
+		<-taskCtx.Done()
+		done <- taskCtx.Err()
+		return taskCtx.Err()
+	}, WithCancelToken(token))
+	if err != nil {
+		t.Fatalf("SubmitCtx failed: %v", err)
+	}
+
+	<-started
+	if err := pool.Cancel(token); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Task was not cancelled")
+	}
+}
+
+// тест отмены неизвестного id
+func TestCancelUnknownToken(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	if err := pool.Cancel(NewCancelToken()); !errors.Is(err, ErrUnknownTask) {
+		t.Errorf("Expected ErrUnknownTask, got %v", err)
+	}
+}
+
+// тест отмены контекста задачи при остановке пула во время её выполнения
+func TestSubmitCtxStopDuringExecution(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+
+	err := pool.SubmitCtx(func(taskCtx context.Context) error {
+		close(started)
+		<-taskCtx.Done()
+		done <- taskCtx.Err()
+		return taskCtx.Err()
+	})
+	if err != nil {
+		t.Fatalf("SubmitCtx failed: %v", err)
+	}
+
+	<-started
+	go pool.Stop()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Task context was not cancelled on Stop")
+	}
+}
@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// TaskID - идентификатор задачи, отправленной через SubmitWithResult
+type TaskID uint64
+
+// Future - представляет результат задачи, который станет доступен по завершению её выполнения
+type Future struct {
+	done   chan struct{}
+	once   sync.Once
+	mu     sync.RWMutex
+	result interface{}
+	err    error
+}
+
+// Done возвращает канал, который закрывается после того как результат станет доступен
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result возвращает результат задачи (валиден только после закрытия канала Done)
+func (f *Future) Result() interface{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.result
+}
+
+// Err возвращает ошибку задачи, если она завершилась с ошибкой, либо пул был остановлен раньше выполнения
+func (f *Future) Err() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.err
+}
+
+// resolve записывает результат задачи и закрывает канал done; безопасен к повторному вызову
+func (f *Future) resolve(result interface{}, err error) {
+	f.once.Do(func() {
+		f.mu.Lock()
+		f.result = result
+		f.err = err
+		f.mu.Unlock()
+		close(f.done)
+	})
+}
+
+// lastTaskID - счетчик для генерации монотонно возрастающих TaskID
+var lastTaskID uint64
+
+// nextTaskID генерирует очередной уникальный TaskID
+func nextTaskID() TaskID {
+	return TaskID(atomic.AddUint64(&lastTaskID, 1))
+}
+
+// SubmitWithResult добавляет задачу в пул и возвращает её TaskID вместе с ошибкой отправки в очередь
+func (p *WorkerPool) SubmitWithResult(task func() (interface{}, error)) (TaskID, error) {
+	id := nextTaskID()
+	future := &Future{done: make(chan struct{})}
+	p.futures.Store(id, future)
+
+	err := p.Submit(func() {
+		result, taskErr := task()
+		future.resolve(result, taskErr)
+	})
+	if err != nil {
+		// задача не попала в очередь - сразу резолвим future ошибкой отправки
+		future.resolve(nil, err)
+	}
+	return id, err
+}
+
+// WaitForTask дожидается результата задачи с данным TaskID либо отмены контекста.
+// Запись о задаче удаляется из p.futures в любом случае - и когда результат дождались,
+// и когда ушли по отмене ctx, - чтобы долгоживущий пул не копил в памяти future каждой
+// когда-либо отправленной задачи. Как следствие, повторный WaitForTask с тем же TaskID
+// после отмены вернет ErrUnknownTask, даже если задача еще выполняется
+func (p *WorkerPool) WaitForTask(ctx context.Context, id TaskID) (interface{}, error) {
+	v, ok := p.futures.Load(id)
+	if !ok {
+		return nil, ErrUnknownTask
+	}
+	future := v.(*Future)
+	defer p.futures.Delete(id)
+
+	select {
+	case <-future.Done():
+		return future.Result(), future.Err()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
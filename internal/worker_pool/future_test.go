@@ -0,0 +1,121 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// тест успешного получения результата задачи
+func TestSubmitWithResult(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	id, err := pool.SubmitWithResult(func() (interface{}, error) {
+		return 42, nil
+	})
+	if err != nil {
+		t.Fatalf("SubmitWithResult failed: %v", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	result, err := pool.WaitForTask(waitCtx, id)
+	if err != nil {
+		t.Fatalf("WaitForTask returned error: %v", err)
+	}
+	if result != 42 {
+		t.Errorf("Expected result 42, got %v", result)
+	}
+}
+
+// тест получения ошибки выполненной задачи через future
+func TestSubmitWithResultError(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	wantErr := errors.New("boom")
+	id, err := pool.SubmitWithResult(func() (interface{}, error) {
+		return nil, wantErr
+	})
+	if err != nil {
+		t.Fatalf("SubmitWithResult failed: %v", err)
+	}
+
+	result, err := pool.WaitForTask(context.Background(), id)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Expected %v, got %v", wantErr, err)
+	}
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
+	}
+}
+
+// тест запроса несуществующего id задачи
+func TestWaitForUnknownTask(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	_, err := pool.WaitForTask(context.Background(), TaskID(999999))
+	if !errors.Is(err, ErrUnknownTask) {
+		t.Errorf("Expected ErrUnknownTask, got %v", err)
+	}
+}
+
+// тест на то, что WaitForTask удаляет future из p.futures и тогда, когда первым
+// срабатывает отмена ctx вызывающего, а не только когда дожидаются результата
+func TestWaitForTaskCtxCancelledCleansUpFuture(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	id, err := pool.SubmitWithResult(func() (interface{}, error) {
+		<-block
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("SubmitWithResult failed: %v", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := pool.WaitForTask(waitCtx, id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	if _, err := pool.WaitForTask(context.Background(), id); !errors.Is(err, ErrUnknownTask) {
+		t.Errorf("Expected future to be cleaned up after ctx cancellation, got %v", err)
+	}
+}
+
+// тест на резолв future с ErrPoolStopped, если задача не успела выполниться до остановки
+func TestSubmitWithResultPoolStopped(t *testing.T) {
+	ctx := context.Background()
+	// пул без воркеров - ни одна задача из очереди не будет выполнена
+	pool := NewWorkerPool(ctx, 0, 5, nil)
+
+	id, err := pool.SubmitWithResult(func() (interface{}, error) {
+		return "should not run", nil
+	})
+	if err != nil {
+		t.Fatalf("SubmitWithResult failed: %v", err)
+	}
+
+	pool.Stop()
+
+	result, err := pool.WaitForTask(context.Background(), id)
+	if !errors.Is(err, ErrPoolStopped) {
+		t.Errorf("Expected ErrPoolStopped, got %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected nil result, got %v", result)
+	}
+}
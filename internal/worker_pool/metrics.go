@@ -0,0 +1,100 @@
+package workerpool
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option - функциональная опция для настройки WorkerPool при создании
+type Option func(*WorkerPool)
+
+// poolMetrics - набор Prometheus-метрик, которыми инструментируется пул при подключении WithMetrics
+type poolMetrics struct {
+	tasksSubmitted       prometheus.Counter
+	tasksCompleted       prometheus.Counter
+	tasksRejectedFull    prometheus.Counter
+	tasksRejectedStopped prometheus.Counter
+	workersBusy          prometheus.Gauge
+	queueDepth           prometheus.Gauge
+	taskDuration         *prometheus.HistogramVec
+}
+
+// WithMetrics подключает к пулу набор Prometheus-метрик, зарегистрированных в reg под заданным namespace:
+// количество занятых воркеров, глубина очереди, счетчики отправленных/выполненных/отклоненных задач
+// (отдельно для ErrQueueFull и ErrPoolStopped) и гистограмма длительности выполнения задач по имени
+func WithMetrics(reg prometheus.Registerer, namespace string) Option {
+	return func(p *WorkerPool) {
+		m := &poolMetrics{
+			tasksSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tasks_submitted_total",
+				Help:      "Total number of tasks accepted into the queue",
+			}),
+			tasksCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tasks_completed_total",
+				Help:      "Total number of tasks executed to completion",
+			}),
+			tasksRejectedFull: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tasks_rejected_queue_full_total",
+				Help:      "Total number of tasks rejected because the queue was full",
+			}),
+			tasksRejectedStopped: prometheus.NewCounter(prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tasks_rejected_pool_stopped_total",
+				Help:      "Total number of tasks rejected because the pool was stopped",
+			}),
+			workersBusy: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "workers_busy",
+				Help:      "Number of workers currently executing a task",
+			}),
+			queueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "queue_depth",
+				Help:      "Number of tasks currently waiting in the queue",
+			}),
+			taskDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "task_duration_seconds",
+				Help:      "Task execution duration in seconds, labeled by task name",
+			}, []string{"task"}),
+		}
+		reg.MustRegister(
+			m.tasksSubmitted,
+			m.tasksCompleted,
+			m.tasksRejectedFull,
+			m.tasksRejectedStopped,
+			m.workersBusy,
+			m.queueDepth,
+			m.taskDuration,
+		)
+		p.metrics = m
+	}
+}
+
+// Stats - снимок состояния пула для вызывающих, которым не нужен Prometheus
+type Stats struct {
+	WorkersTotal             int
+	WorkersBusy              int64
+	QueueDepth               int
+	TasksSubmitted           uint64
+	TasksCompleted           uint64
+	TasksRejectedQueueFull   uint64
+	TasksRejectedPoolStopped uint64
+}
+
+// Stats возвращает текущий снимок счетчиков и состояния пула
+func (p *WorkerPool) Stats() Stats {
+	return Stats{
+		WorkersTotal:             p.WorkerCount(),
+		WorkersBusy:              atomic.LoadInt64(&p.workersBusy),
+		QueueDepth:               p.queue.Len(),
+		TasksSubmitted:           atomic.LoadUint64(&p.tasksSubmitted),
+		TasksCompleted:           atomic.LoadUint64(&p.tasksCompleted),
+		TasksRejectedQueueFull:   atomic.LoadUint64(&p.tasksRejectedFull),
+		TasksRejectedPoolStopped: atomic.LoadUint64(&p.tasksRejectedStopped),
+	}
+}
@@ -0,0 +1,72 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// тест снимка Stats() без подключения Prometheus
+func TestStatsWithoutPrometheus(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+	defer pool.Stop()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if err := pool.Submit(func() { defer wg.Done() }); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	wg.Wait()
+
+	stats := pool.Stats()
+	if stats.TasksSubmitted != 1 {
+		t.Errorf("Expected TasksSubmitted=1, got %d", stats.TasksSubmitted)
+	}
+	if stats.WorkersTotal != 1 {
+		t.Errorf("Expected WorkersTotal=1, got %d", stats.WorkersTotal)
+	}
+}
+
+// тест инкремента Prometheus-счетчиков и накопления гистограммы
+func TestWithMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil, WithMetrics(reg, "test"))
+	defer pool.Stop()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if err := pool.SubmitNamed("demo", func() {
+		defer wg.Done()
+		time.Sleep(10 * time.Millisecond)
+	}); err != nil {
+		t.Fatalf("SubmitNamed failed: %v", err)
+	}
+	wg.Wait()
+
+	// даем метрикам время примениться после завершения задачи
+	time.Sleep(20 * time.Millisecond)
+
+	if got := testutil.ToFloat64(pool.metrics.tasksSubmitted); got != 1 {
+		t.Errorf("Expected tasksSubmitted=1, got %v", got)
+	}
+	if got := testutil.ToFloat64(pool.metrics.tasksCompleted); got != 1 {
+		t.Errorf("Expected tasksCompleted=1, got %v", got)
+	}
+	if got := testutil.CollectAndCount(pool.metrics.taskDuration); got != 1 {
+		t.Errorf("Expected one task_duration_seconds series for task \"demo\", got %d", got)
+	}
+
+	pool.Stop()
+	if err := pool.Submit(func() {}); err != ErrPoolStopped {
+		t.Errorf("Expected ErrPoolStopped, got %v", err)
+	}
+	if got := testutil.ToFloat64(pool.metrics.tasksRejectedStopped); got < 1 {
+		t.Errorf("Expected at least one rejected-stopped submission, got %v", got)
+	}
+}
@@ -0,0 +1,234 @@
+//go:build postgres
+
+package workerpool
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// JobType - строковый идентификатор типа задачи, используемый для диспетчеризации обработчика
+type JobType string
+
+// Job - персистентная задача, хранимая в Postgres-backed очереди
+type Job struct {
+	ID          int64
+	Type        JobType
+	Payload     json.RawMessage
+	RunAt       time.Time
+	Priority    int
+	Attempts    int
+	MaxAttempts int
+}
+
+// JobHandler - обработчик задач конкретного типа, вызываемый при её выполнении
+type JobHandler func(ctx context.Context, payload json.RawMessage) error
+
+// ErrNoHandler возвращается, когда для типа задачи не зарегистрирован обработчик
+var ErrNoHandler = errors.New("error. No handler registered for job type")
+
+// HandlerRegistry сопоставляет типы задач с их обработчиками для диспетчеризации при Dequeue
+type HandlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[JobType]JobHandler
+}
+
+// NewHandlerRegistry создает пустой реестр обработчиков
+func NewHandlerRegistry() *HandlerRegistry {
+	return &HandlerRegistry{handlers: make(map[JobType]JobHandler)}
+}
+
+// Register регистрирует обработчик для заданного типа задачи
+func (r *HandlerRegistry) Register(jobType JobType, handler JobHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[jobType] = handler
+}
+
+func (r *HandlerRegistry) handlerFor(jobType JobType) (JobHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[jobType]
+	return h, ok
+}
+
+// PostgresQueue - реализация Queue поверх таблицы Postgres, вдохновленная стратегией
+// блокировки gue (SELECT ... FOR UPDATE SKIP LOCKED): несколько процессов-владельцев пула
+// могут безопасно делить одну очередь, а задачи переживают перезапуск процесса.
+// Ожидается таблица со схемой:
+//
+//	CREATE TABLE worker_pool_jobs (
+//	    id           bigserial PRIMARY KEY,
+//	    job_type     text NOT NULL,
+//	    payload      jsonb NOT NULL,
+//	    run_at       timestamptz NOT NULL DEFAULT now(),
+//	    priority     integer NOT NULL DEFAULT 0,
+//	    attempts     integer NOT NULL DEFAULT 0,
+//	    max_attempts integer NOT NULL DEFAULT 5,
+//	    locked_until timestamptz
+//	);
+//
+// locked_until реализует лизинг: строка удаляется только после успешного выполнения
+// обработчика, а не до него, поэтому падение процесса во время обработки не теряет
+// задачу - после истечения lease её подхватит SELECT ... FOR UPDATE SKIP LOCKED другого
+// воркера (своего рода fencing, как в gue)
+type PostgresQueue struct {
+	db        *sql.DB
+	registry  *HandlerRegistry
+	tableName string
+}
+
+// postgresPollInterval - с каким интервалом Dequeue повторяет попытку, пока нет
+// ни одной готовой строки, чтобы соответствовать блокирующему контракту Dequeue
+// у chanQueue, а не возвращать ошибку на каждый вызов без готовых задач
+const postgresPollInterval = 200 * time.Millisecond
+
+// postgresLeaseDuration - на какое время задача считается захваченной текущим воркером.
+// Должна быть не меньше времени, отведенного обработчику на выполнение (см. execCtx ниже),
+// иначе lease истечет и другой воркер подберет задачу, пока она еще выполняется
+const postgresLeaseDuration = 5 * time.Minute
+
+// NewPostgresQueue создает очередь поверх уже существующей таблицы worker_pool_jobs
+func NewPostgresQueue(db *sql.DB, registry *HandlerRegistry) *PostgresQueue {
+	return &PostgresQueue{db: db, registry: registry, tableName: "worker_pool_jobs"}
+}
+
+// EnqueueJob сохраняет задачу для выполнения не раньше runAt, сериализуя payload в JSON
+func (q *PostgresQueue) EnqueueJob(ctx context.Context, jobType JobType, payload interface{}, runAt time.Time, priority int) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = q.db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (job_type, payload, run_at, priority, attempts, max_attempts)
+		VALUES ($1, $2, $3, $4, 0, 5)
+	`, q.tableName), jobType, data, runAt, priority)
+	return err
+}
+
+// Enqueue не поддерживается для персистентной очереди - произвольные closure-задачи
+// нельзя сериализовать между процессами. Используйте EnqueueJob с зарегистрированным типом
+func (q *PostgresQueue) Enqueue(task Task) error {
+	return errors.New("error. PostgresQueue does not support Enqueue(Task); use EnqueueJob instead")
+}
+
+// Dequeue блокирует одну готовую к выполнению задачу через SELECT ... FOR UPDATE SKIP LOCKED
+// и возвращает Task, диспетчеризирующую её в обработчик, зарегистрированный по типу задачи.
+// Пока готовых строк нет, опрашивает таблицу с интервалом postgresPollInterval, как того
+// требует блокирующий контракт Dequeue - возвращается либо при находке задачи, либо при
+// отмене ctx, либо при реальной ошибке базы
+func (q *PostgresQueue) Dequeue(ctx context.Context) (Task, error) {
+	for {
+		task, err := q.dequeueOnce(ctx)
+		switch {
+		case err == nil:
+			return task, nil
+		case errors.Is(err, sql.ErrNoRows), errors.Is(err, ErrNoHandler):
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(postgresPollInterval):
+			}
+		default:
+			return nil, err
+		}
+	}
+}
+
+// dequeueOnce делает одну попытку захватить готовую задачу. Обработчик проверяется
+// до коммита - если его нет, транзакция откатывается и задача остается в таблице
+// для следующей попытки вместо того, чтобы быть безвозвратно потерянной из-за
+// опечатки в типе или рассинхронизации деплоя. Сама строка не удаляется: вместо
+// этого ей выставляется locked_until (lease) - удаление происходит только после
+// успешного выполнения обработчика, возвращенного ниже Task-ом, так что падение
+// процесса во время выполнения обработчика не теряет задачу безвозвратно
+func (q *PostgresQueue) dequeueOnce(ctx context.Context) (Task, error) {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	row := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT id, job_type, payload, run_at, priority, attempts, max_attempts
+		FROM %s
+		WHERE run_at <= now() AND (locked_until IS NULL OR locked_until <= now())
+		ORDER BY priority DESC, run_at ASC
+		FOR UPDATE SKIP LOCKED
+		LIMIT 1
+	`, q.tableName))
+
+	if err := row.Scan(&job.ID, &job.Type, &job.Payload, &job.RunAt, &job.Priority, &job.Attempts, &job.MaxAttempts); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	handler, ok := q.registry.handlerFor(job.Type)
+	if !ok {
+		tx.Rollback()
+		return nil, fmt.Errorf("%w: %s", ErrNoHandler, job.Type)
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET locked_until = $1 WHERE id = $2`, q.tableName),
+		time.Now().Add(postgresLeaseDuration), job.ID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		execCtx, cancel := context.WithTimeout(context.Background(), postgresLeaseDuration)
+		defer cancel()
+
+		if err := handler(execCtx, job.Payload); err != nil {
+			if rerr := q.retry(context.Background(), job); rerr != nil {
+				log.Printf("postgres queue: failed to requeue job %d (type %s) after handler error %q: %v",
+					job.ID, job.Type, err, rerr)
+			}
+			return
+		}
+
+		if _, err := q.db.ExecContext(context.Background(), fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, q.tableName), job.ID); err != nil {
+			log.Printf("postgres queue: failed to delete completed job %d (type %s): %v", job.ID, job.Type, err)
+		}
+	}, nil
+}
+
+// retry переставляет задачу на повторное выполнение с экспоненциальной задержкой, если
+// остались попытки, либо удаляет ее как исчерпавшую лимит. Ошибка возвращается вызывающему
+// (dequeueOnce), а не отбрасывается, чтобы сбой переотправки был виден, а не молча ронял job
+func (q *PostgresQueue) retry(ctx context.Context, job Job) error {
+	if job.Attempts+1 >= job.MaxAttempts {
+		_, err := q.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, q.tableName), job.ID)
+		return err
+	}
+	backoff := time.Duration(math.Pow(2, float64(job.Attempts))) * time.Second
+	_, err := q.db.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE %s SET run_at = $1, attempts = $2, locked_until = NULL WHERE id = $3
+	`, q.tableName), time.Now().Add(backoff), job.Attempts+1, job.ID)
+	return err
+}
+
+// Len возвращает число задач, готовых к выполнению (run_at уже наступил и lease не активен)
+func (q *PostgresQueue) Len() int {
+	var count int
+	row := q.db.QueryRow(fmt.Sprintf(`
+		SELECT count(*) FROM %s WHERE run_at <= now() AND (locked_until IS NULL OR locked_until <= now())
+	`, q.tableName))
+	_ = row.Scan(&count)
+	return count
+}
+
+// Close закрывает пул соединений с базой
+func (q *PostgresQueue) Close() error {
+	return q.db.Close()
+}
@@ -0,0 +1,153 @@
+package workerpool
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// priorityTask - элемент очереди с приоритетом; seq используется для сохранения FIFO порядка
+// между задачами одного приоритета
+type priorityTask struct {
+	task     Task
+	priority int
+	seq      uint64
+}
+
+// priorityQueue - куча задач, упорядоченная по приоритету (выше приоритет - ближе к вершине),
+// а при равном приоритете - по порядку отправки
+type priorityQueue []*priorityTask
+
+func (q priorityQueue) Len() int { return len(q) }
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q priorityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue) Push(x interface{}) {
+	*q = append(*q, x.(*priorityTask))
+}
+
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// PriorityWorkerPool - пул воркеров, выбирающий для выполнения задачу с наивысшим приоритетом
+type PriorityWorkerPool struct {
+	mu              sync.Mutex
+	cond            *sync.Cond
+	queue           priorityQueue
+	queueSize       int
+	levels          int
+	nextSeq         uint64
+	workerWaitGroup sync.WaitGroup
+	afterTaskHook   func()
+	isStopped       bool
+	cancel          context.CancelFunc
+}
+
+// NewPriorityWorkerPool создает пул с поддержкой приоритетных задач.
+// levels задает количество уровней приоритета (0..levels-1), queueSize - ёмкость очереди.
+// SubmitWithPriority не проверяет, что priority попадает в диапазон 0..levels-1:
+// принимается любое значение int, и задачи упорядочиваются по нему как есть.
+func NewPriorityWorkerPool(ctx context.Context, workers, queueSize, levels int, hook func()) *PriorityWorkerPool {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	p := &PriorityWorkerPool{
+		queueSize:     queueSize,
+		levels:        levels,
+		cancel:        cancelFunc,
+		afterTaskHook: hook,
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	// горутина, будящая воркеров при отмене контекста, чтобы они могли завершиться
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	}()
+
+	for i := 0; i < workers; i++ {
+		p.workerWaitGroup.Add(1)
+		go func() {
+			defer p.workerWaitGroup.Done()
+			p.process(ctx)
+		}()
+	}
+	return p
+}
+
+// process - основной цикл воркера: дожидается появления задачи с наивысшим приоритетом
+func (p *PriorityWorkerPool) process(ctx context.Context) {
+	for {
+		p.mu.Lock()
+		for p.queue.Len() == 0 && !p.isStopped && ctx.Err() == nil {
+			p.cond.Wait()
+		}
+		if p.queue.Len() == 0 {
+			p.mu.Unlock()
+			return
+		}
+		item := heap.Pop(&p.queue).(*priorityTask)
+		p.mu.Unlock()
+
+		item.task()
+		if p.afterTaskHook != nil {
+			p.afterTaskHook()
+		}
+	}
+}
+
+// Submit добавляет задачу с приоритетом по умолчанию (0) - реализация интерфейса Pool
+func (p *PriorityWorkerPool) Submit(task func()) error {
+	return p.SubmitWithPriority(task, 0)
+}
+
+// SubmitWithPriority добавляет задачу в очередь с указанным приоритетом.
+// Чем выше значение priority, тем раньше задача будет выбрана на выполнение;
+// задачи с одинаковым приоритетом выполняются в порядке отправки (FIFO)
+func (p *PriorityWorkerPool) SubmitWithPriority(task func(), priority int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.isStopped {
+		return ErrPoolStopped
+	}
+	if p.queue.Len() >= p.queueSize {
+		return ErrQueueFull
+	}
+
+	p.nextSeq++
+	heap.Push(&p.queue, &priorityTask{task: task, priority: priority, seq: p.nextSeq})
+	p.cond.Signal()
+	return nil
+}
+
+// Stop останавливает прием новых задач и дожидается, пока воркеры выполнят все,
+// что уже было принято в очередь, включая задачи, ожидавшие своей очереди на момент
+// остановки - так же, как это делает WorkerPool.Stop
+func (p *PriorityWorkerPool) Stop() error {
+	p.mu.Lock()
+	if p.isStopped {
+		p.mu.Unlock()
+		return nil
+	}
+	p.isStopped = true
+	p.cancel()
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	p.workerWaitGroup.Wait()
+	return nil
+}
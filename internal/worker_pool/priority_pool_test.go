@@ -0,0 +1,174 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// тест: задача с высоким приоритетом, отправленная позже задач с низким приоритетом,
+// должна выполниться раньше них, когда единственный воркер занят
+func TestPriorityOrdering(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPriorityWorkerPool(ctx, 1, 10, 3, nil)
+	defer pool.Stop()
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	wg.Add(4)
+
+	block := make(chan struct{})
+	// занимаем единственного воркера, чтобы остальные задачи успели встать в очередь
+	err := pool.SubmitWithPriority(func() {
+		defer wg.Done()
+		<-block
+	}, 0)
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	submit := func(priority, id int) {
+		err := pool.SubmitWithPriority(func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+		}, priority)
+		if err != nil {
+			t.Fatalf("SubmitWithPriority failed: %v", err)
+		}
+	}
+
+	submit(0, 1) // низкий приоритет
+	submit(0, 2) // низкий приоритет
+	submit(2, 3) // высокий приоритет, отправлен последним
+
+	close(block)
+	wg.Wait()
+
+	if len(order) != 3 || order[0] != 3 {
+		t.Errorf("Expected high priority task (3) to run first, got order %v", order)
+	}
+}
+
+// тест: задачи с одинаковым приоритетом выполняются в порядке отправки
+func TestPriorityFIFOTieBreak(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPriorityWorkerPool(ctx, 1, 10, 3, nil)
+	defer pool.Stop()
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	block := make(chan struct{})
+	pool.SubmitWithPriority(func() {
+		defer wg.Done()
+		<-block
+	}, 0)
+
+	for i := 1; i <= 2; i++ {
+		id := i
+		pool.SubmitWithPriority(func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+		}, 1)
+	}
+
+	close(block)
+	wg.Wait()
+
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Errorf("Expected FIFO order [1 2], got %v", order)
+	}
+}
+
+// тест на переполнение очереди приоритетного пула
+func TestPriorityQueueFull(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPriorityWorkerPool(ctx, 1, 1, 3, nil)
+	defer pool.Stop()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	err := pool.SubmitWithPriority(func() { <-block }, 0)
+	if err != nil {
+		t.Fatalf("first submit should succeed, got %v", err)
+	}
+
+	// даем воркеру время забрать первую задачу из очереди
+	time.Sleep(20 * time.Millisecond)
+
+	if err := pool.SubmitWithPriority(func() {}, 0); err != nil {
+		t.Fatalf("second submit should succeed, got %v", err)
+	}
+	if err := pool.SubmitWithPriority(func() {}, 0); err != ErrQueueFull {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+}
+
+// тест остановки приоритетного пула
+func TestPriorityStop(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPriorityWorkerPool(ctx, 2, 10, 3, nil)
+
+	if err := pool.Stop(); err != nil {
+		t.Errorf("Stop failed: %v", err)
+	}
+
+	if err := pool.SubmitWithPriority(func() {}, 0); err != ErrPoolStopped {
+		t.Errorf("Expected ErrPoolStopped, got %v", err)
+	}
+}
+
+// тест на то, что Stop дожидается выполнения задач, уже принятых в очередь,
+// а не просто отбрасывает их - так же, как WorkerPool.Stop
+func TestPriorityStopDrainsQueue(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPriorityWorkerPool(ctx, 1, 10, 3, nil)
+
+	var mu sync.Mutex
+	executed := make(map[int]bool)
+
+	started := make(chan struct{})
+	block := make(chan struct{})
+
+	// занимаем единственного воркера, чтобы остальные задачи остались в очереди
+	if err := pool.SubmitWithPriority(func() {
+		close(started)
+		<-block
+	}, 0); err != nil {
+		t.Fatalf("SubmitWithPriority failed: %v", err)
+	}
+	<-started
+
+	for i := 1; i <= 3; i++ {
+		id := i
+		if err := pool.SubmitWithPriority(func() {
+			mu.Lock()
+			executed[id] = true
+			mu.Unlock()
+		}, 0); err != nil {
+			t.Fatalf("SubmitWithPriority failed: %v", err)
+		}
+	}
+
+	close(block)
+	if err := pool.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 1; i <= 3; i++ {
+		if !executed[i] {
+			t.Errorf("Expected task %d queued before Stop to be executed, it was not", i)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrQueueClosed возвращается Dequeue, когда очередь закрыта и исчерпана
+var ErrQueueClosed = errors.New("error. Queue is closed")
+
+// Queue - абстракция очереди задач. Позволяет подменить очередь по умолчанию
+// (буферизованный канал в памяти) на персистентную реализацию, например Postgres-backed,
+// не меняя остальной код пула
+type Queue interface {
+	Enqueue(task Task) error
+	Dequeue(ctx context.Context) (Task, error)
+	Len() int
+	Close() error
+}
+
+// BlockingEnqueuer - опциональное расширение Queue для реализаций, способных эффективно
+// ждать появления свободного места вместо опроса Enqueue по таймеру (так умеет chanQueue
+// поверх канала). WorkerPool.SubmitBlocking использует его через type assertion, если
+// подключенная Queue его реализует, и опрашивает Enqueue только для тех, что не реализуют
+type BlockingEnqueuer interface {
+	EnqueueBlocking(ctx context.Context, task Task) error
+}
+
+// WithQueue подменяет очередь пула по умолчанию (chanQueue в памяти) на произвольную
+// реализацию Queue, например PostgresQueue - для персистентности задач между перезапусками
+func WithQueue(q Queue) Option {
+	return func(p *WorkerPool) {
+		p.queue = q
+	}
+}
+
+// chanQueue - реализация Queue по умолчанию поверх буферизованного канала
+type chanQueue struct {
+	ch chan Task
+}
+
+// newChanQueue создает очередь в памяти заданной ёмкости
+func newChanQueue(size int) *chanQueue {
+	return &chanQueue{ch: make(chan Task, size)}
+}
+
+// Enqueue кладет задачу в очередь, не блокируясь - при переполнении возвращает ErrQueueFull
+func (q *chanQueue) Enqueue(task Task) error {
+	select {
+	case q.ch <- task:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// EnqueueBlocking кладет задачу в очередь, ожидая освобождения места, если она переполнена.
+// Использует тот же канал, что и Enqueue, поэтому FIFO-порядок между ними сохраняется
+func (q *chanQueue) EnqueueBlocking(ctx context.Context, task Task) error {
+	select {
+	case q.ch <- task:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dequeue забирает следующую задачу. Сначала всегда пытается неблокирующе прочитать канал,
+// и только если он пуст - ждет либо появления задачи, либо отмены ctx. Это гарантирует, что
+// задачи, уже лежащие в буфере на момент отмены ctx (например, при Stop пула), будут забраны
+// и выполнены, а не потеряны из-за того, что select между двумя готовыми case выбирается
+// равновероятно
+func (q *chanQueue) Dequeue(ctx context.Context) (Task, error) {
+	select {
+	case task, ok := <-q.ch:
+		if !ok {
+			return nil, ErrQueueClosed
+		}
+		return task, nil
+	default:
+	}
+
+	select {
+	case task, ok := <-q.ch:
+		if !ok {
+			return nil, ErrQueueClosed
+		}
+		return task, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Len возвращает текущее число задач, ожидающих в очереди
+func (q *chanQueue) Len() int {
+	return len(q.ch)
+}
+
+// Close закрывает очередь; последующий Enqueue приведет к панике, поэтому
+// вызывающий должен гарантировать отсутствие отправителей на момент закрытия
+func (q *chanQueue) Close() error {
+	close(q.ch)
+	return nil
+}
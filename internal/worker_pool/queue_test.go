@@ -0,0 +1,104 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// тест на базовую отправку и получение задачи через очередь по умолчанию
+func TestChanQueueEnqueueDequeue(t *testing.T) {
+	q := newChanQueue(1)
+
+	executed := false
+	if err := q.Enqueue(func() { executed = true }); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	task, err := q.Dequeue(context.Background())
+	if err != nil {
+		t.Fatalf("Dequeue failed: %v", err)
+	}
+	task()
+
+	if !executed {
+		t.Error("Dequeued task was not the one enqueued")
+	}
+}
+
+// тест переполнения очереди
+func TestChanQueueFull(t *testing.T) {
+	q := newChanQueue(1)
+	if err := q.Enqueue(func() {}); err != nil {
+		t.Fatalf("first Enqueue failed: %v", err)
+	}
+	if err := q.Enqueue(func() {}); err != ErrQueueFull {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+}
+
+// тест отмены ожидания Dequeue по контексту
+func TestChanQueueDequeueCtxCancelled(t *testing.T) {
+	q := newChanQueue(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := q.Dequeue(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+// тест на закрытие очереди
+func TestChanQueueClose(t *testing.T) {
+	q := newChanQueue(1)
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	_, err := q.Dequeue(context.Background())
+	if err != ErrQueueClosed {
+		t.Errorf("Expected ErrQueueClosed, got %v", err)
+	}
+}
+
+// countingQueue оборачивает chanQueue, считая число вызовов Enqueue - используется
+// ниже, чтобы убедиться, что WorkerPool реально ходит через подключенную опцией
+// WithQueue реализацию Queue, а не игнорирует ее
+type countingQueue struct {
+	*chanQueue
+	enqueued int
+}
+
+func (q *countingQueue) Enqueue(task Task) error {
+	if err := q.chanQueue.Enqueue(task); err != nil {
+		return err
+	}
+	q.enqueued++
+	return nil
+}
+
+// тест на то, что WorkerPool действительно использует очередь, подключенную через WithQueue,
+// а не встроенную по умолчанию
+func TestWorkerPoolUsesCustomQueue(t *testing.T) {
+	ctx := context.Background()
+	q := &countingQueue{chanQueue: newChanQueue(10)}
+	pool := NewWorkerPool(ctx, 1, 10, nil, WithQueue(q))
+	defer pool.Stop()
+
+	done := make(chan struct{})
+	if err := pool.Submit(func() { close(done) }); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("task submitted through WithQueue was never executed")
+	}
+
+	if q.enqueued != 1 {
+		t.Errorf("Expected custom queue to observe 1 Enqueue call, got %d", q.enqueued)
+	}
+}
@@ -0,0 +1,106 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// тест увеличения числа воркеров
+func TestResizeGrow(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 2, 10, nil)
+	defer pool.Stop()
+
+	if pool.WorkerCount() != 2 {
+		t.Fatalf("Expected 2 workers, got %d", pool.WorkerCount())
+	}
+
+	if err := pool.Resize(5); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	if pool.WorkerCount() != 5 {
+		t.Errorf("Expected 5 workers after resize, got %d", pool.WorkerCount())
+	}
+}
+
+// тест уменьшения числа воркеров
+func TestResizeShrink(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 5, 10, nil)
+	defer pool.Stop()
+
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	if pool.WorkerCount() != 1 {
+		t.Errorf("Expected 1 worker after resize, got %d", pool.WorkerCount())
+	}
+
+	// убеждаемся что оставшийся воркер все еще обрабатывает задачи
+	done := make(chan struct{})
+	if err := pool.Submit(func() { close(done) }); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Task was not processed after shrinking the pool")
+	}
+}
+
+// тест на отрицательное число воркеров
+func TestResizeNegative(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 2, 10, nil)
+	defer pool.Stop()
+
+	if err := pool.Resize(-1); err != ErrInvalidWorkerCount {
+		t.Errorf("Expected ErrInvalidWorkerCount, got %v", err)
+	}
+}
+
+// тест что Resize на остановленном пуле возвращает ошибку
+func TestResizeStoppedPool(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 2, 10, nil)
+	pool.Stop()
+
+	if err := pool.Resize(3); err != ErrPoolStopped {
+		t.Errorf("Expected ErrPoolStopped, got %v", err)
+	}
+}
+
+// тест автомасштабирования: при переполнении очереди число воркеров должно расти
+func TestAutoscaleUp(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{
+		NumberOfWorkers:  1,
+		QueueSize:        10,
+		AutoScale:        true,
+		MinWorkers:       1,
+		MaxWorkers:       4,
+		ScaleUpThreshold: 1,
+		CheckInterval:    10 * time.Millisecond,
+	}
+	pool := NewWorkerPoolWithConfig(ctx, cfg)
+	defer pool.Stop()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	// занимаем единственного воркера и копим задачи в очереди
+	pool.Submit(func() { <-block })
+	for i := 0; i < 3; i++ {
+		pool.Submit(func() { <-block })
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if pool.WorkerCount() > 1 {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("Expected autoscaler to grow pool beyond 1 worker, got %d", pool.WorkerCount())
+}
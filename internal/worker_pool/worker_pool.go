@@ -4,13 +4,20 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
-	ErrPoolStopped = errors.New("error. Worker pool is stopped")
-	ErrQueueFull   = errors.New("error. Worker pool's queue is full")
+	ErrPoolStopped        = errors.New("error. Worker pool is stopped")
+	ErrQueueFull          = errors.New("error. Worker pool's queue is full")
+	ErrUnknownTask        = errors.New("error. Unknown task id")
+	ErrInvalidWorkerCount = errors.New("error. Worker count cannot be negative")
 )
 
+// Pool - общий контракт для реализаций воркер пула. Stop должен остановить прием
+// новых задач и дождаться выполнения всего, что уже было принято в очередь,
+// прежде чем вернуть управление - реализации не должны молча терять принятые задачи
 type Pool interface {
 	Submit(task func()) error
 	Stop() error
@@ -18,81 +25,222 @@ type Pool interface {
 
 type Task func()
 
+// poolWorker - управляющая структура одного воркера, позволяющая остановить его индивидуально
+type poolWorker struct {
+	cancel context.CancelFunc
+}
+
 type WorkerPool struct {
-	taskQueue       chan Task          //очередь задач
+	ctx             context.Context    //контекст пула, используется при создании новых воркеров через Resize
+	queue           Queue              //очередь задач, по умолчанию chanQueue, подменяется опцией WithQueue
 	workerWaitGroup sync.WaitGroup     //wait group для управления воркерами
 	mu              sync.RWMutex       //мьютекс для избежания race condition
 	afterTaskHook   func()             //хук выполненной задачи
 	isStopped       bool               //флаг остановки
 	cancel          context.CancelFunc //функция остановки
+	futures         sync.Map           //хранилище future-ов по TaskID, заполняется через SubmitWithResult
+	workers         []*poolWorker      //текущий набор воркеров, позволяет менять их число через Resize
+	cancelFuncs     sync.Map           //cancel-функции задач, отправленных через SubmitCtx с WithCancelToken
+	stopCh          chan struct{}      //закрывается в Stop, будит блокирующие SubmitBlocking/SubmitTimeout
+	blockingWG      sync.WaitGroup     //отслеживает отправителей, блокированных в ожидании места в очереди
+
+	metrics *poolMetrics //набор prometheus-метрик, подключается опцией WithMetrics
+
+	workersBusy          int64  //число воркеров, занятых выполнением задачи в данный момент
+	tasksSubmitted       uint64 //счетчик успешно отправленных в очередь задач
+	tasksCompleted       uint64 //счетчик выполненных задач
+	tasksRejectedFull    uint64 //счетчик отказов из-за переполнения очереди
+	tasksRejectedStopped uint64 //счетчик отказов из-за остановленного пула
 }
 
-func NewWorkerPool(ctx context.Context, numOfWorkers, queueSize int, hook func()) *WorkerPool {
+func NewWorkerPool(ctx context.Context, numOfWorkers, queueSize int, hook func(), opts ...Option) *WorkerPool {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	wp := &WorkerPool{
 		isStopped:     false,
-		taskQueue:     make(chan Task, queueSize),
+		ctx:           ctx,
+		queue:         newChanQueue(queueSize),
 		cancel:        cancelFunc,
 		afterTaskHook: hook,
+		stopCh:        make(chan struct{}),
 	}
-	//обрабатываем задачи с помощью воркеров
-	for i := 0; i < numOfWorkers; i++ {
-		wp.workerWaitGroup.Add(1)
-		go func(workerId int) {
-			defer wp.workerWaitGroup.Done()
-			wp.process(ctx)
-		}(i)
+	for _, opt := range opts {
+		opt(wp)
 	}
+	//обрабатываем задачи с помощью воркеров
+	wp.mu.Lock()
+	wp.addWorkersLocked(numOfWorkers)
+	wp.mu.Unlock()
 	return wp
 }
 
-// основная функция обработки тасок из очереди
+// addWorkersLocked запускает n дополнительных воркеров; вызывающий должен держать p.mu
+func (p *WorkerPool) addWorkersLocked(n int) {
+	for i := 0; i < n; i++ {
+		workerCtx, cancel := context.WithCancel(p.ctx)
+		w := &poolWorker{cancel: cancel}
+		p.workers = append(p.workers, w)
+		p.workerWaitGroup.Add(1)
+		go func(ctx context.Context) {
+			defer p.workerWaitGroup.Done()
+			p.process(ctx)
+		}(workerCtx)
+	}
+}
+
+// основная функция обработки тасок из очереди. Инструментация (busy-gauge,
+// счетчики, гистограмма длительности) навешивается на задачу еще при отправке
+// в submitNamed, так как Queue.Dequeue отдает только голый Task без имени
 func (p *WorkerPool) process(ctx context.Context) {
 	for {
-		select {
-		case <-ctx.Done():
+		task, err := p.queue.Dequeue(ctx)
+		if err != nil {
 			return
-		case task, ok := <-p.taskQueue:
-			if !ok {
-				return
-			}
-			task()
-			// вызываем хук после выполнения задачи, если он установлен
-			if p.afterTaskHook != nil {
-				p.afterTaskHook()
+		}
+
+		task()
+
+		// вызываем хук после выполнения задачи, если он установлен
+		if p.afterTaskHook != nil {
+			p.afterTaskHook()
+		}
+	}
+}
+
+// instrument оборачивает задачу учетом busy-gauge, счетчика выполненных задач
+// и гистограммы длительности по имени - вызывается при отправке, а не при разборе
+// очереди, чтобы инструментация не зависела от конкретной реализации Queue
+func (p *WorkerPool) instrument(name string, task Task) Task {
+	return func() {
+		atomic.AddInt64(&p.workersBusy, 1)
+		if p.metrics != nil {
+			p.metrics.workersBusy.Inc()
+			p.metrics.queueDepth.Set(float64(p.queue.Len()))
+		}
+
+		start := time.Now()
+		task()
+		duration := time.Since(start)
+
+		atomic.AddInt64(&p.workersBusy, -1)
+		atomic.AddUint64(&p.tasksCompleted, 1)
+		if p.metrics != nil {
+			p.metrics.workersBusy.Dec()
+			p.metrics.tasksCompleted.Inc()
+			label := name
+			if label == "" {
+				label = "default"
 			}
+			p.metrics.taskDuration.WithLabelValues(label).Observe(duration.Seconds())
 		}
 	}
 }
 
+// Resize меняет количество работающих воркеров на лету.
+// При увеличении запускаются дополнительные горутины, при уменьшении
+// лишним воркерам отменяется их персональный контекст, что останавливает их process()
+func (p *WorkerPool) Resize(n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n < 0 {
+		return ErrInvalidWorkerCount
+	}
+	if p.isStopped {
+		return ErrPoolStopped
+	}
+
+	current := len(p.workers)
+	switch {
+	case n > current:
+		p.addWorkersLocked(n - current)
+	case n < current:
+		surplus := p.workers[n:]
+		p.workers = p.workers[:n]
+		for _, w := range surplus {
+			w.cancel()
+		}
+	}
+	return nil
+}
+
+// WorkerCount возвращает текущее количество воркеров в пуле
+func (p *WorkerPool) WorkerCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.workers)
+}
+
 // функция  добавления задачи в пул
 func (p *WorkerPool) Submit(task func()) error {
+	return p.submitNamed("", task)
+}
+
+// SubmitNamed добавляет задачу в пул, помечая её именем для разметки метрики длительности выполнения
+func (p *WorkerPool) SubmitNamed(name string, task func()) error {
+	return p.submitNamed(name, task)
+}
+
+func (p *WorkerPool) submitNamed(name string, task func()) error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
 	if p.isStopped {
+		atomic.AddUint64(&p.tasksRejectedStopped, 1)
+		if p.metrics != nil {
+			p.metrics.tasksRejectedStopped.Inc()
+		}
 		return ErrPoolStopped
 	}
-	select {
-	case p.taskQueue <- task:
-		return nil
-	default:
-		return ErrQueueFull
+
+	if err := p.queue.Enqueue(p.instrument(name, task)); err != nil {
+		atomic.AddUint64(&p.tasksRejectedFull, 1)
+		if p.metrics != nil {
+			p.metrics.tasksRejectedFull.Inc()
+		}
+		return err
+	}
+
+	p.recordSubmitted()
+	return nil
+}
+
+// recordSubmitted обновляет счетчики успешно отправленной в очередь задачи.
+// Вынесено отдельно, чтобы SubmitBlocking могло отчитаться об успехе без прохождения
+// через submitNamed и, соответственно, без ложного инкремента tasksRejectedFull на
+// каждой промежуточной попытке ожидания места в очереди
+func (p *WorkerPool) recordSubmitted() {
+	atomic.AddUint64(&p.tasksSubmitted, 1)
+	if p.metrics != nil {
+		p.metrics.tasksSubmitted.Inc()
+		p.metrics.queueDepth.Set(float64(p.queue.Len()))
 	}
 }
 
 // функция остановки воркер пула - все добавленные в очередь задачи выполняются
 func (p *WorkerPool) Stop() error {
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
 	if p.isStopped {
+		p.mu.Unlock()
 		return nil
 	}
 
 	p.isStopped = true
-	p.cancel()               //вызываем cancelFunc для отмены контекста и остановки воркеров
-	close(p.taskQueue)       // закрываем канал задач
+	p.cancel()         //вызываем cancelFunc для отмены контекста и остановки воркеров
+	close(p.stopCh)    //будим блокирующие SubmitBlocking/SubmitTimeout вызовы
+	p.mu.Unlock()
+
+	p.blockingWG.Wait() //дожидаемся завершения блокирующих отправителей, прежде чем закрыть очередь
+
+	p.queue.Close()          // закрываем очередь задач
 	p.workerWaitGroup.Wait() //ждем завершения всех воркеров
+
+	// резолвим future-ы задач, которые не успели выполниться до остановки пула
+	// (resolve идемпотентен, поэтому уже выполненные задачи останутся со своим результатом).
+	// Записи остаются в p.futures, чтобы WaitForTask после Stop вернул ErrPoolStopped;
+	// удаляет запись сам WaitForTask
+	p.futures.Range(func(key, value interface{}) bool {
+		value.(*Future).resolve(nil, ErrPoolStopped)
+		return true
+	})
 	return nil
 }
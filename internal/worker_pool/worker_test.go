@@ -102,6 +102,53 @@ func TestStopWaitsForCompletion(t *testing.T) {
 	mu.Unlock()
 }
 
+// тест на то, что Stop дожидается выполнения не только уже выполняющейся задачи,
+// но и всего оставшегося в очереди бэклога - так же, как TestPriorityStopDrainsQueue
+// проверяет это для PriorityWorkerPool
+func TestStopDrainsQueuedBacklog(t *testing.T) {
+	ctx := context.Background()
+	pool := NewWorkerPool(ctx, 1, 10, nil)
+
+	var mu sync.Mutex
+	executed := make(map[int]bool)
+
+	started := make(chan struct{})
+	block := make(chan struct{})
+
+	// занимаем единственного воркера, чтобы остальные задачи остались в очереди
+	if err := pool.Submit(func() {
+		close(started)
+		<-block
+	}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	<-started
+
+	for i := 1; i <= 3; i++ {
+		id := i
+		if err := pool.Submit(func() {
+			mu.Lock()
+			executed[id] = true
+			mu.Unlock()
+		}); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+
+	close(block)
+	if err := pool.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 1; i <= 3; i++ {
+		if !executed[i] {
+			t.Errorf("Expected task %d queued before Stop to be executed, it was not", i)
+		}
+	}
+}
+
 // тест на нескольких воркеров
 func TestMultipleWorkers(t *testing.T) {
 	ctx := context.Background()